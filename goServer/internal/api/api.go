@@ -7,7 +7,12 @@ import (
 	"net/http"
 	"net/mail"
 	"server/internal/api/spec"
+	"server/internal/authn"
+	"server/internal/authz"
+	"server/internal/notifier"
 	"server/internal/pgstore"
+	"server/internal/webhook"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,26 +37,55 @@ type store interface {
 	CreateActivity(ctx context.Context, arg pgstore.CreateActivityParams) (uuid.UUID, error)
 	GetParticipants(ctx context.Context, tripID uuid.UUID) ([]pgstore.Participant, error)
 	InviteParticipantToTrip(ctx context.Context, params pgstore.InviteParticipantsToTripParams) (uuid.UUID, error)
+	InviteParticipantsToTripBulk(ctx context.Context, pool *pgxpool.Pool, tripID uuid.UUID, emails []string) ([]pgstore.BulkInviteResult, error)
 	GetTripLinks(ctx context.Context, tripID uuid.UUID) ([]pgstore.Link, error)
 	CreateTripLink(ctx context.Context, arg pgstore.CreateTripLinkParams) (uuid.UUID, error)
-}
-
-type mailer interface {
-	SendTripConfirmationEmail(tripID uuid.UUID) error
-	SendTripConfirmedEmail(tripID, participantID uuid.UUID) error
+	GetTripCompletion(ctx context.Context, tripID uuid.UUID) (pgstore.TripCompletion, error)
+	CompleteTrip(ctx context.Context, arg pgstore.CompleteTripParams) error
+	ReopenTrip(ctx context.Context, tripID uuid.UUID) error
+	SetParticipantChannelPreferences(ctx context.Context, participantID uuid.UUID, channels []string) error
+	GetParticipantChannelPreferences(ctx context.Context, participantID uuid.UUID) ([]string, error)
 }
 
 type API struct {
-	store     store
-	logger    *zap.Logger
-	validator *validator.Validate
-	pool      *pgxpool.Pool
-	mailer    mailer
+	store      store
+	logger     *zap.Logger
+	validator  *validator.Validate
+	pool       *pgxpool.Pool
+	notifier   *notifier.Notifier
+	authorizer *authz.Authorizer
+	webhooks   *webhook.Manager
 }
 
-func NewAPI(pool *pgxpool.Pool, logger *zap.Logger, mailer mailer) API {
+func NewAPI(pool *pgxpool.Pool, logger *zap.Logger, notifier *notifier.Notifier, authorizer *authz.Authorizer, webhooks *webhook.Manager) API {
 	validator := validator.New(validator.WithRequiredStructEnabled())
-	return API{pgstore.New(pool), logger, validator, pool, mailer}
+	return API{pgstore.New(pool), logger, validator, pool, notifier, authorizer, webhooks}
+}
+
+// identity returns the caller resolved by authn.Middleware, or
+// authn.ErrUnauthenticated when the request never went through it.
+func (api API) identity(r *http.Request) (authn.Identity, error) {
+	identity, ok := authn.IdentityFromContext(r.Context())
+	if !ok {
+		return authn.Identity{}, authn.ErrUnauthenticated
+	}
+	return identity, nil
+}
+
+// recipientChannels looks up the channels a participant asked to be
+// notified on. An empty slice (no preference recorded, or a lookup failure)
+// lets notifier.Send fall back to email.
+func (api API) recipientChannels(ctx context.Context, participantID uuid.UUID) []notifier.Channel {
+	prefs, err := api.store.GetParticipantChannelPreferences(ctx, participantID)
+	if err != nil {
+		api.logger.Warn("failed to load channel preferences", zap.Error(err), zap.String("participant_id", participantID.String()))
+		return nil
+	}
+	channels := make([]notifier.Channel, len(prefs))
+	for i, c := range prefs {
+		channels[i] = notifier.Channel(c)
+	}
+	return channels
 }
 
 // Confirms a participant on a trip.
@@ -80,6 +114,13 @@ func (api API) PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *h
 		)
 	}
 
+	identity, err := api.identity(r)
+	if err != nil || identity.Email != participant.Email {
+		return spec.PatchParticipantsParticipantIDConfirmJSON400Response(
+			spec.Error{Message: "forbidden"},
+		)
+	}
+
 	if err := api.store.ConfirmParticipant(r.Context(), id); err != nil {
 		api.logger.Error("Failed to confirm participant", zap.Error(err), zap.String("participant_id", participantID))
 
@@ -88,9 +129,65 @@ func (api API) PatchParticipantsParticipantIDConfirm(w http.ResponseWriter, r *h
 		)
 	}
 
+	if err := api.authorizer.GrantViewer(r.Context(), identity.UserID, participant.TripID); err != nil {
+		api.logger.Error("failed to grant viewer policy", zap.Error(err), zap.String("participant_id", participantID))
+	}
+
+	if err := api.webhooks.Emit(r.Context(), participant.TripID, webhook.EventParticipantConfirmed, map[string]string{
+		"participant_id": id.String(),
+		"trip_id":        participant.TripID.String(),
+	}); err != nil {
+		api.logger.Error("failed to emit participant confirmed webhook event", zap.Error(err), zap.String("participant_id", participantID))
+	}
+
 	return spec.PatchParticipantsParticipantIDConfirmJSON204Response(nil)
 }
 
+// Set which channels (email, sms, push, webhook) a participant wants to be
+// notified on, in priority order.
+// (PATCH /participants/{participantId}/channel-preferences)
+func (api API) PatchParticipantsParticipantIDChannelPreferences(w http.ResponseWriter, r *http.Request, participantID string) *spec.Response {
+	id, err := uuid.Parse(participantID)
+	if err != nil {
+		return spec.PatchParticipantsParticipantIDChannelPreferencesJSON400Response(spec.Error{Message: "uuid invalido"})
+	}
+
+	participant, err := api.store.GetParticipant(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return spec.PatchParticipantsParticipantIDChannelPreferencesJSON400Response(spec.Error{Message: "Participante não encontrado"})
+		}
+		api.logger.Error("Failed to get participant", zap.Error(err), zap.String("participant_id", participantID))
+		return spec.PatchParticipantsParticipantIDChannelPreferencesJSON400Response(
+			spec.Error{Message: "Something went wrong, try again later"},
+		)
+	}
+
+	identity, err := api.identity(r)
+	if err != nil || identity.Email != participant.Email {
+		return spec.PatchParticipantsParticipantIDChannelPreferencesJSON400Response(
+			spec.Error{Message: "forbidden"},
+		)
+	}
+
+	var body spec.PatchParticipantsParticipantIDChannelPreferencesJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PatchParticipantsParticipantIDChannelPreferencesJSON400Response(spec.Error{Message: "invalid JSON: " + err.Error()})
+	}
+	if err := api.validator.Struct(body); err != nil {
+		return spec.PatchParticipantsParticipantIDChannelPreferencesJSON400Response(spec.Error{Message: "invalid input: " + err.Error()})
+	}
+
+	if err := api.store.SetParticipantChannelPreferences(r.Context(), id, body.Channels); err != nil {
+		api.logger.Error("failed to set channel preferences", zap.Error(err), zap.String("participant_id", participantID))
+		return spec.PatchParticipantsParticipantIDChannelPreferencesJSON400Response(
+			spec.Error{Message: "Something went wrong, try again later"},
+		)
+	}
+
+	return spec.PatchParticipantsParticipantIDChannelPreferencesJSON204Response(nil)
+}
+
 // Create a new trip
 // (POST /trips)
 func (api API) PostTrips(w http.ResponseWriter, r *http.Request) *spec.Response {
@@ -103,21 +200,70 @@ func (api API) PostTrips(w http.ResponseWriter, r *http.Request) *spec.Response
 		return spec.PostTripsJSON400Response(spec.Error{Message: "invalid input: " + err.Error()})
 	}
 
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.PostTripsJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+
 	tripID, err := api.store.CreateTrip(r.Context(), api.pool, body)
 	if err != nil {
 		return spec.PostTripsJSON400Response(spec.Error{Message: "Failed to create trip, try again later: " + err.Error()})
 
 	}
 
-	go func() {
-		if err := api.mailer.SendTripConfirmationEmail(tripID); err != nil {
-			api.logger.Error("failed to send email on PostTrips", zap.Error(err), zap.String("trip_id", tripID.String()))
-		}
-	}()
+	if err := api.authorizer.GrantOwner(r.Context(), identity.UserID, tripID); err != nil {
+		api.logger.Error("failed to grant owner policy", zap.Error(err), zap.String("trip_id", tripID.String()))
+	}
+
+	recipient := notifier.Recipient{Email: string(body.OwnerEmail)}
+	data := notifier.Data{"owner_name": body.OwnerName, "destination": body.Destination, "trip_id": tripID.String()}
+	if err := api.notifier.Send(r.Context(), notifier.TemplateTripCreated, recipient, data); err != nil {
+		api.logger.Error("failed to enqueue trip created notification", zap.Error(err), zap.String("trip_id", tripID.String()))
+	}
+
+	if err := api.webhooks.Emit(r.Context(), tripID, webhook.EventTripCreated, map[string]string{
+		"trip_id":     tripID.String(),
+		"destination": body.Destination,
+	}); err != nil {
+		api.logger.Error("failed to emit trip created webhook event", zap.Error(err), zap.String("trip_id", tripID.String()))
+	}
 
 	return spec.PostTripsJSON201Response(spec.CreateTripResponse{TripID: tripID.String()})
 }
 
+// List the trips the caller has read access to.
+// (GET /trips)
+func (api API) GetTrips(w http.ResponseWriter, r *http.Request) *spec.Response {
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.GetTripsJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+
+	tripIDs, err := api.authorizer.ListObjects(r.Context(), identity.UserID, authz.PermissionTripRead)
+	if err != nil {
+		api.logger.Error("failed to list authorized trips", zap.Error(err), zap.String("subject", identity.UserID.String()))
+		return spec.GetTripsJSON400Response(spec.Error{Message: "something went wrong, try again"})
+	}
+
+	var output spec.GetTripsResponse
+	for _, id := range tripIDs {
+		trip, err := api.store.GetTrip(r.Context(), id)
+		if err != nil {
+			api.logger.Error("failed to get trip for listing", zap.Error(err), zap.String("trip_id", id.String()))
+			continue
+		}
+		output.Trips = append(output.Trips, spec.GetTripsResponseArray{
+			ID:          trip.ID.String(),
+			Destination: trip.Destination,
+			EndsAt:      trip.EndsAt.Time,
+			IsConfirmed: trip.IsConfirmed,
+			StartsAt:    trip.StartsAt.Time,
+		})
+	}
+
+	return spec.GetTripsJSON200Response(output)
+}
+
 // Get a trip details.
 // (GET /trips/{tripId})
 func (api API) GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
@@ -125,24 +271,69 @@ func (api API) GetTripsTripID(w http.ResponseWriter, r *http.Request, tripID str
 	if err != nil {
 		return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "uuid invalido"})
 	}
+
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripRead); err != nil {
+		return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
 	trip, err := api.store.GetTrip(r.Context(), id)
 	if err != nil {
 		return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "Failed to get trip"})
 	}
 
+	completion, err := api.store.GetTripCompletion(r.Context(), id)
+	if err != nil {
+		return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "Failed to get trip"})
+	}
+
 	response := spec.GetTripDetailsResponse{
 		Trip: spec.GetTripDetailsResponseTripObj{
-			ID:          trip.ID.String(),
-			Destination: trip.Destination,
-			EndsAt:      trip.EndsAt.Time,
-			IsConfirmed: trip.IsConfirmed,
-			StartsAt:    trip.StartsAt.Time,
+			ID:              trip.ID.String(),
+			Destination:     trip.Destination,
+			EndsAt:          trip.EndsAt.Time,
+			IsConfirmed:     trip.IsConfirmed,
+			StartsAt:        trip.StartsAt.Time,
+			IsComplete:      completion.IsComplete,
+			EndedAt:         timestampPtr(completion.EndedAt),
+			Rating:          int2Ptr(completion.Rating),
+			DistanceKm:      numericPtr(completion.DistanceKm),
+			AverageSpeedKmh: numericPtr(completion.AverageSpeedKmh),
+			Notes:           completion.Notes,
 		},
 	}
 
 	return spec.GetTripsTripIDJSON200Response(response)
 }
 
+func timestampPtr(t pgtype.Timestamp) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func int2Ptr(i pgtype.Int2) *int16 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int16
+}
+
+func numericPtr(n pgtype.Numeric) *float64 {
+	if !n.Valid {
+		return nil
+	}
+	f, err := n.Float64Value()
+	if err != nil || !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
 // Update a trip.
 // (PUT /trips/{tripId})
 func (api API) PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
@@ -161,11 +352,34 @@ func (api API) PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID str
 		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "invalid uuid"})
 	}
 
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripUpdate); err != nil {
+		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
 	trip, err := api.store.GetTrip(r.Context(), id)
 	if err != nil {
 		return spec.GetTripsTripIDJSON400Response(spec.Error{Message: "Failed to get trip"})
 	}
 
+	completion, err := api.store.GetTripCompletion(r.Context(), id)
+	if err != nil {
+		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "Failed to get trip"})
+	}
+
+	reopen := r.URL.Query().Get("reopen") == "true"
+	if completion.IsComplete && !reopen {
+		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "trip is complete, pass ?reopen=true to edit it"})
+	}
+	if completion.IsComplete && reopen {
+		if err := api.store.ReopenTrip(r.Context(), id); err != nil {
+			return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "Failed to reopen trip"})
+		}
+	}
+
 	newData := pgstore.UpdateTripParams{
 		Destination: body.Destination,
 		StartsAt:    pgtype.Timestamp{Valid: true, Time: body.StartsAt},
@@ -178,9 +392,138 @@ func (api API) PutTripsTripID(w http.ResponseWriter, r *http.Request, tripID str
 		return spec.PutTripsTripIDJSON400Response(spec.Error{Message: "Failed to update trip"})
 	}
 
+	if err := api.webhooks.Emit(r.Context(), id, webhook.EventTripUpdated, map[string]string{
+		"trip_id":     id.String(),
+		"destination": body.Destination,
+	}); err != nil {
+		api.logger.Error("failed to emit trip updated webhook event", zap.Error(err), zap.String("trip_id", tripID))
+	}
+
 	return spec.PutTripsTripIDJSON204Response(nil)
 }
 
+// Mark a trip complete and record its post-trip telemetry.
+// (POST /trips/{tripId}/complete)
+func (api API) PostTripsTripIDComplete(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDCompleteJSON400Response(spec.Error{Message: "invalid uuid passed: " + err.Error()})
+	}
+
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.PostTripsTripIDCompleteJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripUpdate); err != nil {
+		return spec.PostTripsTripIDCompleteJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
+	var body spec.PostTripsTripIDCompleteJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PostTripsTripIDCompleteJSON400Response(spec.Error{Message: "invalid JSON: " + err.Error()})
+	}
+	if err := api.validator.Struct(body); err != nil {
+		return spec.PostTripsTripIDCompleteJSON400Response(spec.Error{Message: "invalid input: " + err.Error()})
+	}
+
+	if err := api.store.CompleteTrip(r.Context(), pgstore.CompleteTripParams{
+		ID:              id,
+		EndedAt:         pgtype.Timestamp{Valid: true, Time: body.EndedAt},
+		Rating:          int2FromPtr(body.Rating),
+		DistanceKm:      numericFromPtr(body.DistanceKm),
+		AverageSpeedKmh: numericFromPtr(body.AverageSpeedKmh),
+		Notes:           body.Notes,
+	}); err != nil {
+		api.logger.Error("failed to complete trip", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDCompleteJSON400Response(spec.Error{Message: "something went wrong, try again"})
+	}
+
+	return spec.PostTripsTripIDCompleteJSON200Response(spec.CompleteTripResponse{TripID: tripID, IsComplete: true})
+}
+
+func int2FromPtr(i *int16) pgtype.Int2 {
+	if i == nil {
+		return pgtype.Int2{}
+	}
+	return pgtype.Int2{Int16: *i, Valid: true}
+}
+
+func numericFromPtr(f *float64) pgtype.Numeric {
+	if f == nil {
+		return pgtype.Numeric{}
+	}
+	var n pgtype.Numeric
+	if err := n.Scan(strconv.FormatFloat(*f, 'f', -1, 64)); err != nil {
+		return pgtype.Numeric{}
+	}
+	return n
+}
+
+// Summarize a trip's activities, participant confirmations, and links so a
+// client can render a recap without three separate round trips.
+// (GET /trips/{tripId}/summary)
+func (api API) GetTripsTripIDSummary(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.GetTripsTripIDSummaryJSON400Response(spec.Error{Message: "invalid uuid passed: " + err.Error()})
+	}
+
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.GetTripsTripIDSummaryJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripRead); err != nil {
+		return spec.GetTripsTripIDSummaryJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
+	activities, err := api.store.GetTripActivities(r.Context(), id)
+	if err != nil {
+		api.logger.Error("failed to find trip activities", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDSummaryJSON400Response(spec.Error{Message: "something went wrong, try again"})
+	}
+
+	countByDate := make(map[string]int)
+	for _, act := range activities {
+		countByDate[act.OccursAt.Time.Format(time.DateOnly)]++
+	}
+	activitiesByDay := make([]spec.GetTripsTripIDSummaryResponseActivityDay, 0, len(countByDate))
+	for dateStr, count := range countByDate {
+		date, _ := time.Parse(time.DateOnly, dateStr)
+		activitiesByDay = append(activitiesByDay, spec.GetTripsTripIDSummaryResponseActivityDay{Date: date, Count: count})
+	}
+
+	participants, err := api.store.GetParticipants(r.Context(), id)
+	if err != nil {
+		api.logger.Error("failed to find trip participants", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDSummaryJSON400Response(spec.Error{Message: "something went wrong, try again"})
+	}
+	var confirmed int
+	for _, p := range participants {
+		if p.IsConfirmed {
+			confirmed++
+		}
+	}
+	var ratio float64
+	if len(participants) > 0 {
+		ratio = float64(confirmed) / float64(len(participants))
+	}
+
+	links, err := api.store.GetTripLinks(r.Context(), id)
+	if err != nil {
+		api.logger.Error("failed to find trip links", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDSummaryJSON400Response(spec.Error{Message: "something went wrong, try again"})
+	}
+
+	return spec.GetTripsTripIDSummaryJSON200Response(spec.GetTripsTripIDSummaryResponse{
+		TripID:                       tripID,
+		ActivitiesByDay:              activitiesByDay,
+		ParticipantsTotal:            len(participants),
+		ParticipantsConfirmed:        confirmed,
+		ParticipantConfirmationRatio: ratio,
+		LinksCount:                   len(links),
+	})
+}
+
 // Get a trip activities.
 // (GET /trips/{tripId}/activities)
 func (api API) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
@@ -189,6 +532,14 @@ func (api API) GetTripsTripIDActivities(w http.ResponseWriter, r *http.Request,
 		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "uuid invalido"})
 	}
 
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripRead); err != nil {
+		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
 	activities, err := api.store.GetTripActivities(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -236,6 +587,22 @@ func (api API) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request,
 		return spec.GetTripsTripIDActivitiesJSON400Response(spec.Error{Message: "invalid uuid"})
 	}
 
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripUpdate); err != nil {
+		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
+	completion, err := api.store.GetTripCompletion(r.Context(), id)
+	if err != nil {
+		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Failed to get trip"})
+	}
+	if completion.IsComplete {
+		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "cannot add activities to a completed trip"})
+	}
+
 	var body spec.CreateActivityRequest
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "invalid JSON: " + err.Error()})
@@ -254,6 +621,14 @@ func (api API) PostTripsTripIDActivities(w http.ResponseWriter, r *http.Request,
 		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "Failed to create activity, try again later"})
 	}
 
+	if err := api.webhooks.Emit(r.Context(), id, webhook.EventActivityCreated, map[string]string{
+		"trip_id":     id.String(),
+		"activity_id": activityID.String(),
+		"title":       body.Title,
+	}); err != nil {
+		api.logger.Error("failed to emit activity created webhook event", zap.Error(err), zap.String("trip_id", tripID))
+	}
+
 	return spec.PostTripsTripIDActivitiesJSON201Response(spec.CreateActivityResponse{ActivityID: activityID.String()})
 }
 
@@ -265,6 +640,14 @@ func (api API) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tri
 		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "uuid invalido"})
 	}
 
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripUpdate); err != nil {
+		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
 	trip, err := api.store.GetTrip(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -294,6 +677,30 @@ func (api API) GetTripsTripIDConfirm(w http.ResponseWriter, r *http.Request, tri
 		return spec.GetTripsTripIDConfirmJSON400Response(spec.Error{Message: "something went wrong, try again"})
 	}
 
+	participants, err := api.store.GetParticipants(r.Context(), id)
+	if err != nil {
+		api.logger.Error("failed to list participants to notify", zap.Error(err), zap.String("trip_id", tripID))
+	}
+	for _, participant := range participants {
+		recipient := notifier.Recipient{Email: participant.Email, Channels: api.recipientChannels(r.Context(), participant.ID)}
+		data := notifier.Data{"trip_id": tripID, "destination": trip.Destination}
+		if err := api.notifier.Send(r.Context(), notifier.TemplateTripConfirmed, recipient, data); err != nil {
+			api.logger.Error(
+				"failed to enqueue trip confirmed notification",
+				zap.Error(err),
+				zap.String("participant_id", participant.ID.String()),
+				zap.String("trip_id", tripID),
+			)
+		}
+	}
+
+	if err := api.webhooks.Emit(r.Context(), id, webhook.EventTripConfirmed, map[string]string{
+		"trip_id":     tripID,
+		"destination": trip.Destination,
+	}); err != nil {
+		api.logger.Error("failed to emit trip confirmed webhook event", zap.Error(err), zap.String("trip_id", tripID))
+	}
+
 	return spec.GetTripsTripIDConfirmJSON204Response(nil)
 }
 
@@ -305,6 +712,14 @@ func (api API) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tr
 		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "invalid uuid passed: " + err.Error()})
 	}
 
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripInvite); err != nil {
+		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
 	var body spec.PostTripsTripIDInvitesJSONBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: err.Error()})
@@ -333,18 +748,78 @@ func (api API) PostTripsTripIDInvites(w http.ResponseWriter, r *http.Request, tr
 		return spec.PostTripsTripIDInvitesJSON400Response(spec.Error{Message: "something went wrong, try again"})
 	}
 
-	go func() {
-		if err := api.mailer.SendTripConfirmedEmail(id, participantID); err != nil {
+	recipient := notifier.Recipient{Email: string(body.Email), Channels: api.recipientChannels(r.Context(), participantID)}
+	data := notifier.Data{"trip_id": tripID, "participant_id": participantID.String()}
+	if err := api.notifier.Send(r.Context(), notifier.TemplateParticipantInvited, recipient, data); err != nil {
+		api.logger.Error(
+			"failed to enqueue participant invited notification",
+			zap.Error(err),
+			zap.String("participant_id", participantID.String()),
+			zap.String("trip_id", tripID),
+		)
+	}
+
+	if err := api.webhooks.Emit(r.Context(), id, webhook.EventParticipantInvited, map[string]string{
+		"trip_id":        tripID,
+		"participant_id": participantID.String(),
+	}); err != nil {
+		api.logger.Error("failed to emit participant invited webhook event", zap.Error(err), zap.String("trip_id", tripID))
+	}
+
+	return spec.PostTripsTripIDInvitesJSON201Response(nil)
+}
+
+// Invite many participants to the trip in a single request, honoring
+// partial success: one already-invited or malformed email no longer fails
+// every other address in the batch.
+// (POST /trips/{tripId}/invites/bulk)
+func (api API) PostTripsTripIDInvitesBulk(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(spec.Error{Message: "invalid uuid passed: " + err.Error()})
+	}
+
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripInvite); err != nil {
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
+	var body spec.PostTripsTripIDInvitesBulkJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(spec.Error{Message: "invalid JSON: " + err.Error()})
+	}
+	if err := api.validator.Struct(body); err != nil {
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(spec.Error{Message: "invalid input: " + err.Error()})
+	}
+
+	results, err := api.store.InviteParticipantsToTripBulk(r.Context(), api.pool, id, body.Emails)
+	if err != nil {
+		api.logger.Error("failed to bulk invite participants", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDInvitesBulkJSON400Response(spec.Error{Message: "something went wrong, try again"})
+	}
+
+	output := spec.PostTripsTripIDInvitesBulkResponse{Results: make([]spec.PostTripsTripIDInvitesBulkResponseArray, len(results))}
+	for i, result := range results {
+		output.Results[i] = spec.PostTripsTripIDInvitesBulkResponseArray{Email: result.Email, Status: result.Status}
+		if result.Status != "created" {
+			continue
+		}
+		recipient := notifier.Recipient{Email: result.Email}
+		data := notifier.Data{"trip_id": tripID}
+		if err := api.notifier.Send(r.Context(), notifier.TemplateParticipantInvited, recipient, data); err != nil {
 			api.logger.Error(
-				"failed to send trip confirmed email",
+				"failed to enqueue participant invited notification",
 				zap.Error(err),
-				zap.String("participant_id", participantID.String()),
 				zap.String("trip_id", tripID),
+				zap.String("participant_email", result.Email),
 			)
 		}
-	}()
+	}
 
-	return spec.PostTripsTripIDInvitesJSON201Response(nil)
+	return spec.PostTripsTripIDInvitesBulkJSON201Response(output)
 }
 
 // Get a trip links.
@@ -355,6 +830,14 @@ func (api API) GetTripsTripIDLinks(w http.ResponseWriter, r *http.Request, tripI
 		return spec.GetTripsTripIDLinksJSON400Response(spec.Error{Message: "invalid uuid passed: " + err.Error()})
 	}
 
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.GetTripsTripIDLinksJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripRead); err != nil {
+		return spec.GetTripsTripIDLinksJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
 	links, err := api.store.GetTripLinks(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -385,6 +868,14 @@ func (api API) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, trip
 		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "invalid uuid passed: " + err.Error()})
 	}
 
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripUpdate); err != nil {
+		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
 	var body spec.PostTripsTripIDLinksJSONBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		return spec.PostTripsTripIDLinksJSON400Response(spec.Error{Message: err.Error()})
@@ -403,8 +894,15 @@ func (api API) PostTripsTripIDLinks(w http.ResponseWriter, r *http.Request, trip
 		return spec.PostTripsTripIDActivitiesJSON400Response(spec.Error{Message: "something went wrong, try again"})
 	}
 
-	return spec.PostTripsTripIDLinksJSON201Response(spec.CreateLinkResponse{LinkID: linkID.String()})
+	if err := api.webhooks.Emit(r.Context(), id, webhook.EventLinkCreated, map[string]string{
+		"trip_id": tripID,
+		"link_id": linkID.String(),
+		"title":   body.Title,
+	}); err != nil {
+		api.logger.Error("failed to emit link created webhook event", zap.Error(err), zap.String("trip_id", tripID))
+	}
 
+	return spec.PostTripsTripIDLinksJSON201Response(spec.CreateLinkResponse{LinkID: linkID.String()})
 }
 
 // Get a trip participants.
@@ -417,6 +915,14 @@ func (api API) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request
 		)
 	}
 
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.GetTripsTripIDParticipantsJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripRead); err != nil {
+		return spec.GetTripsTripIDParticipantsJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
 	participants, err := api.store.GetParticipants(r.Context(), id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -447,3 +953,146 @@ func (api API) GetTripsTripIDParticipants(w http.ResponseWriter, r *http.Request
 
 	return spec.GetTripsTripIDParticipantsJSON200Response(output)
 }
+
+// Register a webhook subscription. Only the trip owner may manage webhooks.
+// (POST /trips/{tripId}/webhooks)
+func (api API) PostTripsTripIDWebhooks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.PostTripsTripIDWebhooksJSON400Response(spec.Error{Message: "invalid uuid passed: " + err.Error()})
+	}
+
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.PostTripsTripIDWebhooksJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripManageWebhooks); err != nil {
+		return spec.PostTripsTripIDWebhooksJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
+	var body spec.PostTripsTripIDWebhooksJSONBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return spec.PostTripsTripIDWebhooksJSON400Response(spec.Error{Message: "invalid JSON: " + err.Error()})
+	}
+	if err := api.validator.Struct(body); err != nil {
+		return spec.PostTripsTripIDWebhooksJSON400Response(spec.Error{Message: "invalid input: " + err.Error()})
+	}
+
+	created, err := api.webhooks.Subscribe(r.Context(), id, body.URL, body.Events)
+	if err != nil {
+		api.logger.Error("failed to create webhook subscription", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.PostTripsTripIDWebhooksJSON400Response(spec.Error{Message: "something went wrong, try again"})
+	}
+
+	return spec.PostTripsTripIDWebhooksJSON201Response(spec.CreateWebhookResponse{
+		WebhookID: created.ID.String(),
+		Secret:    created.Secret,
+	})
+}
+
+// List the webhook subscriptions registered on a trip.
+// (GET /trips/{tripId}/webhooks)
+func (api API) GetTripsTripIDWebhooks(w http.ResponseWriter, r *http.Request, tripID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.GetTripsTripIDWebhooksJSON400Response(spec.Error{Message: "invalid uuid passed: " + err.Error()})
+	}
+
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.GetTripsTripIDWebhooksJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripManageWebhooks); err != nil {
+		return spec.GetTripsTripIDWebhooksJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
+	webhooks, err := api.webhooks.List(r.Context(), id)
+	if err != nil {
+		api.logger.Error("failed to list webhook subscriptions", zap.Error(err), zap.String("trip_id", tripID))
+		return spec.GetTripsTripIDWebhooksJSON400Response(spec.Error{Message: "something went wrong, try again"})
+	}
+
+	output := spec.GetTripsTripIDWebhooksResponse{Webhooks: make([]spec.GetTripsTripIDWebhooksResponseArray, len(webhooks))}
+	for i, hook := range webhooks {
+		output.Webhooks[i] = spec.GetTripsTripIDWebhooksResponseArray{
+			ID:        hook.ID.String(),
+			URL:       hook.URL,
+			Events:    hook.Events,
+			CreatedAt: hook.CreatedAt.Time,
+		}
+	}
+
+	return spec.GetTripsTripIDWebhooksJSON200Response(output)
+}
+
+// Remove a webhook subscription.
+// (DELETE /trips/{tripId}/webhooks/{id})
+func (api API) DeleteTripsTripIDWebhooksWebhookID(w http.ResponseWriter, r *http.Request, tripID, webhookID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.DeleteTripsTripIDWebhooksWebhookIDJSON400Response(spec.Error{Message: "invalid uuid passed: " + err.Error()})
+	}
+	hookID, err := uuid.Parse(webhookID)
+	if err != nil {
+		return spec.DeleteTripsTripIDWebhooksWebhookIDJSON400Response(spec.Error{Message: "invalid webhook uuid passed: " + err.Error()})
+	}
+
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.DeleteTripsTripIDWebhooksWebhookIDJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripManageWebhooks); err != nil {
+		return spec.DeleteTripsTripIDWebhooksWebhookIDJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
+	if err := api.webhooks.Unsubscribe(r.Context(), id, hookID); err != nil {
+		api.logger.Error("failed to delete webhook subscription", zap.Error(err), zap.String("webhook_id", webhookID))
+		return spec.DeleteTripsTripIDWebhooksWebhookIDJSON400Response(spec.Error{Message: "something went wrong, try again"})
+	}
+
+	return spec.DeleteTripsTripIDWebhooksWebhookIDJSON204Response(nil)
+}
+
+// Debug endpoint listing every delivery attempt for a webhook subscription.
+// (GET /trips/{tripId}/webhooks/{id}/deliveries)
+func (api API) GetTripsTripIDWebhooksWebhookIDDeliveries(w http.ResponseWriter, r *http.Request, tripID, webhookID string) *spec.Response {
+	id, err := uuid.Parse(tripID)
+	if err != nil {
+		return spec.GetTripsTripIDWebhooksWebhookIDDeliveriesJSON400Response(spec.Error{Message: "invalid uuid passed: " + err.Error()})
+	}
+	hookID, err := uuid.Parse(webhookID)
+	if err != nil {
+		return spec.GetTripsTripIDWebhooksWebhookIDDeliveriesJSON400Response(spec.Error{Message: "invalid webhook uuid passed: " + err.Error()})
+	}
+
+	identity, err := api.identity(r)
+	if err != nil {
+		return spec.GetTripsTripIDWebhooksWebhookIDDeliveriesJSON400Response(spec.Error{Message: "unauthorized"})
+	}
+	if err := api.authorizer.Authorize(r.Context(), identity.UserID, id, authz.PermissionTripManageWebhooks); err != nil {
+		return spec.GetTripsTripIDWebhooksWebhookIDDeliveriesJSON400Response(spec.Error{Message: "forbidden"})
+	}
+
+	deliveries, err := api.webhooks.Deliveries(r.Context(), id, hookID)
+	if err != nil {
+		api.logger.Error("failed to list webhook deliveries", zap.Error(err), zap.String("webhook_id", webhookID))
+		return spec.GetTripsTripIDWebhooksWebhookIDDeliveriesJSON400Response(spec.Error{Message: "something went wrong, try again"})
+	}
+
+	output := spec.GetTripsTripIDWebhooksWebhookIDDeliveriesResponse{
+		Deliveries: make([]spec.GetTripsTripIDWebhooksWebhookIDDeliveriesResponseArray, len(deliveries)),
+	}
+	for i, d := range deliveries {
+		output.Deliveries[i] = spec.GetTripsTripIDWebhooksWebhookIDDeliveriesResponseArray{
+			ID:             d.ID.String(),
+			EventID:        d.EventID.String(),
+			EventType:      d.EventType,
+			Status:         d.Status,
+			Attempts:       d.Attempts,
+			ResponseStatus: d.ResponseStatus,
+			ResponseBody:   d.ResponseBody,
+		}
+	}
+
+	return spec.GetTripsTripIDWebhooksWebhookIDDeliveriesJSON200Response(output)
+}
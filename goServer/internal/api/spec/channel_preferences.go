@@ -0,0 +1,16 @@
+package spec
+
+// PatchParticipantsParticipantIDChannelPreferencesJSONBody is the body of
+// PATCH /participants/{participantId}/channel-preferences: the channels a
+// participant wants to be notified on, in priority order.
+type PatchParticipantsParticipantIDChannelPreferencesJSONBody struct {
+	Channels []string `json:"channels" validate:"required,min=1,dive,oneof=email sms push webhook"`
+}
+
+func PatchParticipantsParticipantIDChannelPreferencesJSON204Response(body any) *Response {
+	return &Response{body: body, Code: 204, contentType: "application/json"}
+}
+
+func PatchParticipantsParticipantIDChannelPreferencesJSON400Response(body Error) *Response {
+	return &Response{body: body, Code: 400, contentType: "application/json"}
+}
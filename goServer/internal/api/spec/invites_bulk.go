@@ -0,0 +1,32 @@
+package spec
+
+// PostTripsTripIDInvitesBulkJSONBody is the body of
+// POST /trips/{tripId}/invites/bulk.
+type PostTripsTripIDInvitesBulkJSONBody struct {
+	Emails []string `json:"emails" validate:"required,min=1"`
+}
+
+type PostTripsTripIDInvitesBulkResponseArray struct {
+	Email  string `json:"email"`
+	Status string `json:"status"`
+}
+
+type PostTripsTripIDInvitesBulkResponse struct {
+	Results []PostTripsTripIDInvitesBulkResponseArray `json:"results"`
+}
+
+func PostTripsTripIDInvitesBulkJSON201Response(body PostTripsTripIDInvitesBulkResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        201,
+		contentType: "application/json",
+	}
+}
+
+func PostTripsTripIDInvitesBulkJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
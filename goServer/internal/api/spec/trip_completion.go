@@ -0,0 +1,52 @@
+package spec
+
+import "time"
+
+// PostTripsTripIDCompleteJSONBody is the post-trip recap submitted when
+// marking a trip complete. All fields are optional so a client can close
+// out a trip without having every metric on hand.
+type PostTripsTripIDCompleteJSONBody struct {
+	EndedAt         time.Time `json:"ended_at"`
+	Rating          *int16    `json:"rating" validate:"omitempty,min=1,max=5"`
+	DistanceKm      *float64  `json:"distance_km"`
+	AverageSpeedKmh *float64  `json:"average_speed_kmh"`
+	Notes           string    `json:"notes"`
+}
+
+type CompleteTripResponse struct {
+	TripID     string `json:"trip_id"`
+	IsComplete bool   `json:"is_complete"`
+}
+
+func PostTripsTripIDCompleteJSON200Response(body CompleteTripResponse) *Response {
+	return &Response{body: body, Code: 200, contentType: "application/json"}
+}
+
+func PostTripsTripIDCompleteJSON400Response(body Error) *Response {
+	return &Response{body: body, Code: 400, contentType: "application/json"}
+}
+
+// GetTripsTripIDSummaryResponseActivityDay is the activity count for a
+// single day of the trip, mirroring the grouping GetTripActivitiesResponse
+// already does per day.
+type GetTripsTripIDSummaryResponseActivityDay struct {
+	Date  time.Time `json:"date"`
+	Count int       `json:"count"`
+}
+
+type GetTripsTripIDSummaryResponse struct {
+	TripID                       string                                     `json:"trip_id"`
+	ActivitiesByDay              []GetTripsTripIDSummaryResponseActivityDay `json:"activities_by_day"`
+	ParticipantsTotal            int                                        `json:"participants_total"`
+	ParticipantsConfirmed        int                                        `json:"participants_confirmed"`
+	ParticipantConfirmationRatio float64                                    `json:"participant_confirmation_ratio"`
+	LinksCount                   int                                        `json:"links_count"`
+}
+
+func GetTripsTripIDSummaryJSON200Response(body GetTripsTripIDSummaryResponse) *Response {
+	return &Response{body: body, Code: 200, contentType: "application/json"}
+}
+
+func GetTripsTripIDSummaryJSON400Response(body Error) *Response {
+	return &Response{body: body, Code: 400, contentType: "application/json"}
+}
@@ -0,0 +1,34 @@
+package spec
+
+import "time"
+
+// GetTripsResponseArray is one entry of GET /trips, mirroring
+// GetTripDetailsResponseTripObj without the activities/participants detail
+// a list view doesn't need.
+type GetTripsResponseArray struct {
+	ID          string    `json:"id"`
+	Destination string    `json:"destination"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	IsConfirmed bool      `json:"is_confirmed"`
+}
+
+type GetTripsResponse struct {
+	Trips []GetTripsResponseArray `json:"trips"`
+}
+
+func GetTripsJSON200Response(body GetTripsResponse) *Response {
+	return &Response{
+		body:        body,
+		Code:        200,
+		contentType: "application/json",
+	}
+}
+
+func GetTripsJSON400Response(body Error) *Response {
+	return &Response{
+		body:        body,
+		Code:        400,
+		contentType: "application/json",
+	}
+}
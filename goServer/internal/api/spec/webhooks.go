@@ -0,0 +1,71 @@
+package spec
+
+import "time"
+
+// PostTripsTripIDWebhooksJSONBody registers a new webhook subscription.
+type PostTripsTripIDWebhooksJSONBody struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1"`
+}
+
+type CreateWebhookResponse struct {
+	WebhookID string `json:"webhook_id"`
+	Secret    string `json:"secret"`
+}
+
+func PostTripsTripIDWebhooksJSON201Response(body CreateWebhookResponse) *Response {
+	return &Response{body: body, Code: 201, contentType: "application/json"}
+}
+
+func PostTripsTripIDWebhooksJSON400Response(body Error) *Response {
+	return &Response{body: body, Code: 400, contentType: "application/json"}
+}
+
+type GetTripsTripIDWebhooksResponseArray struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GetTripsTripIDWebhooksResponse struct {
+	Webhooks []GetTripsTripIDWebhooksResponseArray `json:"webhooks"`
+}
+
+func GetTripsTripIDWebhooksJSON200Response(body GetTripsTripIDWebhooksResponse) *Response {
+	return &Response{body: body, Code: 200, contentType: "application/json"}
+}
+
+func GetTripsTripIDWebhooksJSON400Response(body Error) *Response {
+	return &Response{body: body, Code: 400, contentType: "application/json"}
+}
+
+func DeleteTripsTripIDWebhooksWebhookIDJSON204Response(body any) *Response {
+	return &Response{body: body, Code: 204, contentType: "application/json"}
+}
+
+func DeleteTripsTripIDWebhooksWebhookIDJSON400Response(body Error) *Response {
+	return &Response{body: body, Code: 400, contentType: "application/json"}
+}
+
+type GetTripsTripIDWebhooksWebhookIDDeliveriesResponseArray struct {
+	ID             string `json:"id"`
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Attempts       int32  `json:"attempts"`
+	ResponseStatus int32  `json:"response_status"`
+	ResponseBody   string `json:"response_body"`
+}
+
+type GetTripsTripIDWebhooksWebhookIDDeliveriesResponse struct {
+	Deliveries []GetTripsTripIDWebhooksWebhookIDDeliveriesResponseArray `json:"deliveries"`
+}
+
+func GetTripsTripIDWebhooksWebhookIDDeliveriesJSON200Response(body GetTripsTripIDWebhooksWebhookIDDeliveriesResponse) *Response {
+	return &Response{body: body, Code: 200, contentType: "application/json"}
+}
+
+func GetTripsTripIDWebhooksWebhookIDDeliveriesJSON400Response(body Error) *Response {
+	return &Response{body: body, Code: 400, contentType: "application/json"}
+}
@@ -0,0 +1,96 @@
+// Package authn resolves the caller identity for a request and threads it
+// through context, so handlers and the authz package can agree on who's
+// asking without either depending on how the token was issued.
+package authn
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Identity is the authenticated caller of a request.
+type Identity struct {
+	UserID uuid.UUID
+	Email  string
+}
+
+var ErrUnauthenticated = errors.New("authn: missing or invalid credentials")
+
+type contextKey struct{}
+
+// WithIdentity returns a copy of ctx carrying id.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// IdentityFromContext returns the identity a Middleware attached to ctx.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(contextKey{}).(Identity)
+	return id, ok
+}
+
+// Authenticator resolves the Identity making a request.
+type Authenticator interface {
+	Authenticate(r *http.Request) (Identity, error)
+}
+
+// JWTAuthenticator authenticates requests carrying a `Bearer` JWT signed
+// with an HMAC secret, using the `sub` claim as the user id and `email` as
+// the participant email.
+type JWTAuthenticator struct {
+	secret []byte
+}
+
+func NewJWTAuthenticator(secret []byte) *JWTAuthenticator {
+	return &JWTAuthenticator{secret: secret}
+}
+
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (Identity, error) {
+	header := r.Header.Get("Authorization")
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return Identity{}, ErrUnauthenticated
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrUnauthenticated
+		}
+		return a.secret, nil
+	})
+	if err != nil {
+		return Identity{}, ErrUnauthenticated
+	}
+
+	sub, _ := claims["sub"].(string)
+	userID, err := uuid.Parse(sub)
+	if err != nil {
+		return Identity{}, ErrUnauthenticated
+	}
+	email, _ := claims["email"].(string)
+
+	return Identity{UserID: userID, Email: email}, nil
+}
+
+// Middleware attaches the Identity resolved by auth to every request's
+// context, or rejects the request with 401 when it can't.
+func Middleware(auth Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, err := auth.Authenticate(r)
+			if err != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"message":"unauthorized"}`))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), identity)))
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Package idempotency lets retrying clients replay the response of a
+// request they've already made instead of repeating its side effects,
+// following the Idempotency-Key convention used by the Courier SDK.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+	"server/internal/pgstore"
+)
+
+// Header is the HTTP header clients set to make a request idempotent.
+const Header = "Idempotency-Key"
+
+// window is how long a key is honored before it's eligible to be reused
+// for a brand new request.
+const window = 24 * time.Hour
+
+// Store is the persistence Middleware needs to reserve, look up, and record
+// replayed responses.
+type Store interface {
+	ReserveIdempotencyKey(ctx context.Context, arg pgstore.ReserveIdempotencyKeyParams) (bool, error)
+	GetIdempotencyKey(ctx context.Context, key string, tripID uuid.UUID) (pgstore.IdempotencyKey, error)
+	SaveIdempotencyKey(ctx context.Context, arg pgstore.SaveIdempotencyKeyParams) error
+}
+
+// Middleware replays the stored response for a request whose Idempotency-Key
+// was already seen on the same trip, within the last 24h, provided the body
+// hashes match; a reused key with a different body gets a 409, and so does a
+// key that another request with the same key is still handling. Requests
+// without the header pass straight through.
+func Middleware(store Store, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(Header)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, `{"message":"failed to read request body"}`, http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			hash := hashRequest(body)
+			tripID := tripIDFromPath(r.URL.Path)
+
+			reserved, err := store.ReserveIdempotencyKey(r.Context(), pgstore.ReserveIdempotencyKeyParams{
+				Key:         key,
+				TripID:      tripID,
+				RequestHash: hash,
+				ExpiresAt:   pgtype.Timestamp{Valid: true, Time: time.Now().Add(window)},
+			})
+			if err != nil {
+				logger.Error("failed to reserve idempotency key", zap.Error(err), zap.String("idempotency_key", key))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !reserved {
+				existing, err := store.GetIdempotencyKey(r.Context(), key, tripID)
+				switch {
+				case errors.Is(err, pgx.ErrNoRows):
+					// lost the race to a row that's since expired; treat this as
+					// a fresh key rather than blocking the request forever.
+					next.ServeHTTP(w, r)
+					return
+				case err != nil:
+					logger.Error("failed to look up idempotency key", zap.Error(err), zap.String("idempotency_key", key))
+					next.ServeHTTP(w, r)
+					return
+				case existing.RequestHash != hash:
+					http.Error(w, `{"message":"Idempotency-Key reused with a different request body"}`, http.StatusConflict)
+					return
+				case existing.StatusCode == 0:
+					http.Error(w, `{"message":"a request with this Idempotency-Key is already in progress"}`, http.StatusConflict)
+					return
+				default:
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(int(existing.StatusCode))
+					w.Write(existing.ResponseBody)
+					return
+				}
+			}
+
+			recorder := httptest.NewRecorder()
+			next.ServeHTTP(recorder, r)
+
+			for header, values := range recorder.Header() {
+				w.Header()[header] = values
+			}
+			w.WriteHeader(recorder.Code)
+			w.Write(recorder.Body.Bytes())
+
+			err = store.SaveIdempotencyKey(r.Context(), pgstore.SaveIdempotencyKeyParams{
+				Key:          key,
+				TripID:       tripID,
+				ResponseBody: recorder.Body.Bytes(),
+				StatusCode:   int32(recorder.Code),
+				ExpiresAt:    pgtype.Timestamp{Valid: true, Time: time.Now().Add(window)},
+			})
+			if err != nil {
+				logger.Error("failed to save idempotency key", zap.Error(err), zap.String("idempotency_key", key))
+			}
+		})
+	}
+}
+
+func hashRequest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// tripIDFromPath pulls the {tripId} segment out of paths shaped like
+// /trips/{tripId}/... . Requests with no trip in their path (POST /trips)
+// scope to uuid.Nil instead, so their idempotency keys don't collide with
+// any real trip's.
+func tripIDFromPath(path string) uuid.UUID {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) >= 2 && parts[0] == "trips" {
+		if id, err := uuid.Parse(parts[1]); err == nil {
+			return id
+		}
+	}
+	return uuid.Nil
+}
@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// HTTPProvider delivers notifications over the webhook channel: a plain
+// JSON POST to the recipient's WebhookURL. It's what lets users plug in
+// Twilio, Slack, or anything else that can accept a POST.
+type HTTPProvider struct {
+	client *http.Client
+}
+
+// NewHTTPProvider builds an HTTPProvider. A nil client falls back to
+// http.DefaultClient.
+func NewHTTPProvider(client *http.Client) *HTTPProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &HTTPProvider{client: client}
+}
+
+func (p *HTTPProvider) Channel() Channel { return ChannelWebhook }
+
+func (p *HTTPProvider) Send(ctx context.Context, tmpl Template, recipient Recipient, data Data) error {
+	if recipient.WebhookURL == "" {
+		return fmt.Errorf("http provider: recipient has no webhook url")
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"template": tmpl.ID,
+		"data":     data,
+	})
+	if err != nil {
+		return fmt.Errorf("http provider: marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, recipient.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("http provider: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("http provider: send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("http provider: recipient responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Package notifier fans domain events out to whichever channels a Recipient
+// accepts (email, SMS, push, generic webhook). It replaces the old `mailer`
+// interface, which only knew how to send two hard-coded email templates.
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TemplateID identifies a notification template by the domain event that triggers it.
+type TemplateID string
+
+const (
+	TemplateTripCreated        TemplateID = "trip.created"
+	TemplateTripConfirmed      TemplateID = "trip.confirmed"
+	TemplateParticipantInvited TemplateID = "participant.invited"
+	TemplateActivityReminder   TemplateID = "activity.reminder"
+)
+
+// Channel identifies a delivery mechanism a Provider implements.
+type Channel string
+
+const (
+	ChannelEmail   Channel = "email"
+	ChannelSMS     Channel = "sms"
+	ChannelPush    Channel = "push"
+	ChannelWebhook Channel = "webhook"
+)
+
+// Data carries the template variables for a single notification.
+type Data map[string]any
+
+// Recipient describes who a notification is for and how they can be reached.
+// Channels lists the recipient's preferred channels in priority order; when
+// empty the Notifier falls back to email.
+type Recipient struct {
+	UserID     uuid.UUID
+	Email      string
+	Phone      string
+	PushToken  string
+	WebhookURL string
+	Channels   []Channel
+}
+
+// Template is a per-event message definition rendered for a Recipient.
+type Template struct {
+	ID      TemplateID
+	Subject string
+	Body    string
+}
+
+// Provider delivers a rendered Template to a Recipient over a single Channel.
+type Provider interface {
+	Channel() Channel
+	Send(ctx context.Context, tmpl Template, recipient Recipient, data Data) error
+}
+
+// outbox is the persistence the Notifier needs so a crashed goroutine can't
+// silently drop a message: Send only ever enqueues, Worker does the sending.
+type outbox interface {
+	EnqueueNotification(ctx context.Context, arg EnqueueNotificationParams) (uuid.UUID, error)
+}
+
+// Notifier fans a domain event out to every channel a Recipient accepts.
+type Notifier struct {
+	outbox    outbox
+	templates map[TemplateID]Template
+	logger    *zap.Logger
+}
+
+// NewNotifier builds a Notifier backed by the default template registry.
+func NewNotifier(outbox outbox, logger *zap.Logger) *Notifier {
+	return &Notifier{
+		outbox:    outbox,
+		templates: defaultTemplates(),
+		logger:    logger,
+	}
+}
+
+func defaultTemplates() map[TemplateID]Template {
+	return map[TemplateID]Template{
+		TemplateTripCreated: {
+			ID:      TemplateTripCreated,
+			Subject: "Confirme sua viagem",
+			Body:    "trip_created",
+		},
+		TemplateTripConfirmed: {
+			ID:      TemplateTripConfirmed,
+			Subject: "Sua viagem foi confirmada",
+			Body:    "trip_confirmed",
+		},
+		TemplateParticipantInvited: {
+			ID:      TemplateParticipantInvited,
+			Subject: "Você foi convidado para uma viagem",
+			Body:    "participant_invited",
+		},
+		TemplateActivityReminder: {
+			ID:      TemplateActivityReminder,
+			Subject: "Lembrete de atividade",
+			Body:    "activity_reminder",
+		},
+	}
+}
+
+// Send records the notification in the outbox for every channel the
+// recipient accepts and returns once it's durably queued. Actual delivery
+// happens out-of-band in Worker.Run, so a failed send can be retried instead
+// of lost with the goroutine that tried it.
+func (n *Notifier) Send(ctx context.Context, tmplID TemplateID, recipient Recipient, data Data) error {
+	tmpl, ok := n.templates[tmplID]
+	if !ok {
+		return fmt.Errorf("notifier: unknown template %q", tmplID)
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("notifier: marshal data: %w", err)
+	}
+
+	channels := recipient.Channels
+	if len(channels) == 0 {
+		channels = []Channel{ChannelEmail}
+	}
+
+	for _, channel := range channels {
+		_, err := n.outbox.EnqueueNotification(ctx, EnqueueNotificationParams{
+			TemplateID: tmpl.ID,
+			Channel:    channel,
+			Recipient:  recipient,
+			Payload:    payload,
+		})
+		if err != nil {
+			return fmt.Errorf("notifier: enqueue %s notification for %s: %w", channel, tmpl.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"server/internal/pgstore"
+)
+
+// EnqueueNotificationParams is the outbox-level shape of a single channel
+// delivery attempt, before it's flattened into pgstore columns.
+type EnqueueNotificationParams struct {
+	TemplateID TemplateID
+	Channel    Channel
+	Recipient  Recipient
+	Payload    []byte
+}
+
+// PGStore is the subset of pgstore the notifier outbox needs, kept narrow so
+// Notifier and Worker can be tested without a real database.
+type PGStore interface {
+	EnqueueNotification(ctx context.Context, arg pgstore.EnqueueNotificationParams) (uuid.UUID, error)
+	ListPendingNotifications(ctx context.Context, limit int32) ([]pgstore.Notification, error)
+	MarkNotificationSent(ctx context.Context, id uuid.UUID) error
+	MarkNotificationFailed(ctx context.Context, arg pgstore.MarkNotificationFailedParams) error
+}
+
+// pgOutbox adapts a PGStore to the outbox interface Notifier.Send uses.
+type pgOutbox struct {
+	store PGStore
+}
+
+// NewPGOutbox wraps store so it can back a Notifier.
+func NewPGOutbox(store PGStore) *pgOutbox {
+	return &pgOutbox{store: store}
+}
+
+func (o *pgOutbox) EnqueueNotification(ctx context.Context, arg EnqueueNotificationParams) (uuid.UUID, error) {
+	return o.store.EnqueueNotification(ctx, pgstore.EnqueueNotificationParams{
+		TemplateID: string(arg.TemplateID),
+		Channel:    string(arg.Channel),
+		Email:      arg.Recipient.Email,
+		Phone:      arg.Recipient.Phone,
+		PushToken:  arg.Recipient.PushToken,
+		WebhookURL: arg.Recipient.WebhookURL,
+		Payload:    arg.Payload,
+	})
+}
@@ -0,0 +1,91 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net"
+	"net/smtp"
+)
+
+// bodyTemplates renders tmpl.Body ("trip_created", "trip_confirmed", ...)
+// against the notification Data, so a recipient sees prose instead of a
+// dump of map entries. Keep in sync with defaultTemplates' Body values.
+var bodyTemplates = map[string]*template.Template{
+	"trip_created": template.Must(template.New("trip_created").Parse(
+		`<p>Sua viagem para <strong>{{.destination}}</strong> foi criada. Confirme em {{.trip_id}}.</p>`,
+	)),
+	"trip_confirmed": template.Must(template.New("trip_confirmed").Parse(
+		`<p>Sua viagem para <strong>{{.destination}}</strong> foi confirmada!</p>`,
+	)),
+	"participant_invited": template.Must(template.New("participant_invited").Parse(
+		`<p>Você foi convidado para participar de uma viagem. Acesse a viagem {{.trip_id}} para confirmar presença.</p>`,
+	)),
+	"activity_reminder": template.Must(template.New("activity_reminder").Parse(
+		`<p>Lembrete: você tem uma atividade marcada na viagem {{.trip_id}}.</p>`,
+	)),
+}
+
+// SMTPProvider delivers notifications over the email channel.
+type SMTPProvider struct {
+	host, port         string
+	username, password string
+	from               string
+}
+
+// NewSMTPProvider builds an SMTPProvider that authenticates with username
+// and password and sends everything as from.
+func NewSMTPProvider(host, port, username, password, from string) *SMTPProvider {
+	return &SMTPProvider{host: host, port: port, username: username, password: password, from: from}
+}
+
+func (p *SMTPProvider) Channel() Channel { return ChannelEmail }
+
+func (p *SMTPProvider) Send(ctx context.Context, tmpl Template, recipient Recipient, data Data) error {
+	if recipient.Email == "" {
+		return fmt.Errorf("smtp provider: recipient has no email address")
+	}
+
+	var auth smtp.Auth
+	if p.username != "" {
+		auth = smtp.PlainAuth("", p.username, p.password, p.host)
+	}
+
+	msg := buildMessage(p.from, recipient.Email, tmpl, data)
+	addr := net.JoinHostPort(p.host, p.port)
+	return smtp.SendMail(addr, auth, p.from, []string{recipient.Email}, msg)
+}
+
+// buildMessage renders tmpl.Body against data and wraps it in the headers
+// smtp.SendMail expects.
+func buildMessage(from, to string, tmpl Template, data Data) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", tmpl.Subject)
+	buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+	buf.Write(renderBody(tmpl.Body, data))
+	return buf.Bytes()
+}
+
+// renderBody executes the html/template registered for body against data,
+// falling back to a plain key/value dump for an unrecognized body name so a
+// new TemplateID without a registered template still sends something
+// readable instead of failing the send outright.
+func renderBody(body string, data Data) []byte {
+	tmpl, ok := bodyTemplates[body]
+	if !ok {
+		var buf bytes.Buffer
+		for key, value := range data {
+			fmt.Fprintf(&buf, "%s: %v\n", key, value)
+		}
+		return buf.Bytes()
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]any(data)); err != nil {
+		return []byte(fmt.Sprintf("failed to render notification body: %s", err))
+	}
+	return buf.Bytes()
+}
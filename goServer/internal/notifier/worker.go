@@ -0,0 +1,107 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+	"server/internal/pgstore"
+)
+
+const defaultPollInterval = 15 * time.Second
+
+// Worker drains the notifications outbox on a fixed interval and delivers
+// each pending row through the Provider registered for its channel. This is
+// what makes sending durable: PostTrips et al. only ever enqueue.
+type Worker struct {
+	store     PGStore
+	providers map[Channel]Provider
+	logger    *zap.Logger
+	interval  time.Duration
+}
+
+// NewWorker builds a Worker that delivers through providers, keyed by the
+// Channel each one implements.
+func NewWorker(store PGStore, logger *zap.Logger, providers ...Provider) *Worker {
+	byChannel := make(map[Channel]Provider, len(providers))
+	for _, p := range providers {
+		byChannel[p.Channel()] = p
+	}
+	return &Worker{
+		store:     store,
+		providers: byChannel,
+		logger:    logger,
+		interval:  defaultPollInterval,
+	}
+}
+
+// Run blocks, draining the outbox every interval until ctx is canceled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.drain(ctx)
+		}
+	}
+}
+
+func (w *Worker) drain(ctx context.Context) {
+	pending, err := w.store.ListPendingNotifications(ctx, 50)
+	if err != nil {
+		w.logger.Error("failed to list pending notifications", zap.Error(err))
+		return
+	}
+
+	for _, n := range pending {
+		w.deliver(ctx, n)
+	}
+}
+
+func (w *Worker) deliver(ctx context.Context, n pgstore.Notification) {
+	provider, ok := w.providers[Channel(n.Channel)]
+	if !ok {
+		w.logger.Warn("no provider registered for channel", zap.String("channel", n.Channel))
+		if err := w.store.MarkNotificationFailed(ctx, pgstore.MarkNotificationFailedParams{
+			ID:        n.ID,
+			LastError: "no provider registered for channel " + n.Channel,
+		}); err != nil {
+			w.logger.Error("failed to mark notification failed", zap.Error(err), zap.String("notification_id", n.ID.String()))
+		}
+		return
+	}
+
+	var data Data
+	if err := json.Unmarshal(n.Payload, &data); err != nil {
+		w.logger.Error("failed to decode notification payload", zap.Error(err), zap.String("notification_id", n.ID.String()))
+		return
+	}
+
+	recipient := Recipient{
+		Email:      n.Email,
+		Phone:      n.Phone,
+		PushToken:  n.PushToken,
+		WebhookURL: n.WebhookURL,
+	}
+	tmpl := defaultTemplates()[TemplateID(n.TemplateID)]
+
+	if err := provider.Send(ctx, tmpl, recipient, data); err != nil {
+		w.logger.Error("failed to deliver notification", zap.Error(err), zap.String("notification_id", n.ID.String()))
+		if mErr := w.store.MarkNotificationFailed(ctx, pgstore.MarkNotificationFailedParams{
+			ID:        n.ID,
+			LastError: err.Error(),
+		}); mErr != nil {
+			w.logger.Error("failed to mark notification failed", zap.Error(mErr), zap.String("notification_id", n.ID.String()))
+		}
+		return
+	}
+
+	if err := w.store.MarkNotificationSent(ctx, n.ID); err != nil {
+		w.logger.Error("failed to mark notification sent", zap.Error(err), zap.String("notification_id", n.ID.String()))
+	}
+}
@@ -0,0 +1,81 @@
+package pgstore
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// IdempotencyKey is a previously-seen Idempotency-Key request/response pair,
+// scoped to the trip it was made against so the same key reused on a
+// different trip doesn't collide. StatusCode is 0 while the row is reserved
+// but the handler hasn't finished yet.
+type IdempotencyKey struct {
+	Key          string
+	TripID       uuid.UUID
+	RequestHash  string
+	ResponseBody []byte
+	StatusCode   int32
+	ExpiresAt    pgtype.Timestamp
+}
+
+type ReserveIdempotencyKeyParams struct {
+	Key         string
+	TripID      uuid.UUID
+	RequestHash string
+	ExpiresAt   pgtype.Timestamp
+}
+
+const reserveIdempotencyKey = `
+INSERT INTO idempotency_keys (key, trip_id, request_hash, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (key, trip_id) DO NOTHING
+`
+
+// ReserveIdempotencyKey atomically claims (key, tripID) for this request.
+// It reports false when a row already exists for that pair, whether it's
+// still in flight or already holds a replayable response, so two concurrent
+// requests with the same fresh key can't both run the handler.
+func (q *Queries) ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (bool, error) {
+	tag, err := q.db.Exec(ctx, reserveIdempotencyKey, arg.Key, arg.TripID, arg.RequestHash, arg.ExpiresAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+const getIdempotencyKey = `
+SELECT key, trip_id, request_hash, response_body, status_code, expires_at
+FROM idempotency_keys
+WHERE key = $1 AND trip_id = $2 AND expires_at > now()
+`
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, key string, tripID uuid.UUID) (IdempotencyKey, error) {
+	var k IdempotencyKey
+	err := q.db.QueryRow(ctx, getIdempotencyKey, key, tripID).Scan(
+		&k.Key, &k.TripID, &k.RequestHash, &k.ResponseBody, &k.StatusCode, &k.ExpiresAt,
+	)
+	return k, err
+}
+
+type SaveIdempotencyKeyParams struct {
+	Key          string
+	TripID       uuid.UUID
+	ResponseBody []byte
+	StatusCode   int32
+	ExpiresAt    pgtype.Timestamp
+}
+
+const saveIdempotencyKey = `
+UPDATE idempotency_keys
+SET response_body = $3, status_code = $4, expires_at = $5
+WHERE key = $1 AND trip_id = $2
+`
+
+// SaveIdempotencyKey records the handler's response against a key already
+// claimed by ReserveIdempotencyKey.
+func (q *Queries) SaveIdempotencyKey(ctx context.Context, arg SaveIdempotencyKeyParams) error {
+	_, err := q.db.Exec(ctx, saveIdempotencyKey, arg.Key, arg.TripID, arg.ResponseBody, arg.StatusCode, arg.ExpiresAt)
+	return err
+}
@@ -0,0 +1,72 @@
+package pgstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BulkInviteResult is the outcome of inviting a single email as part of a
+// POST /trips/{tripId}/invites/bulk request.
+type BulkInviteResult struct {
+	Email  string
+	Status string // "created", "already_invited" or "invalid"
+}
+
+// InviteParticipantsToTripBulk inserts every valid email as a participant
+// in a single transaction, so one already-invited address doesn't fail the
+// whole batch the way a single pgerrcode.UniqueViolation does today.
+func (q *Queries) InviteParticipantsToTripBulk(ctx context.Context, pool *pgxpool.Pool, tripID uuid.UUID, emails []string) ([]BulkInviteResult, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	results := make([]BulkInviteResult, 0, len(emails))
+
+	for _, email := range emails {
+		if _, err := mail.ParseAddress(email); err != nil {
+			results = append(results, BulkInviteResult{Email: email, Status: "invalid"})
+			continue
+		}
+
+		// Each insert runs under its own savepoint so a unique violation
+		// doesn't abort the surrounding transaction.
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("begin savepoint: %w", err)
+		}
+		_, err = New(sp).InviteParticipantToTrip(ctx, InviteParticipantsToTripParams{TripID: tripID, Email: email})
+		switch {
+		case err == nil:
+			if err := sp.Commit(ctx); err != nil {
+				return nil, fmt.Errorf("release savepoint: %w", err)
+			}
+			results = append(results, BulkInviteResult{Email: email, Status: "created"})
+		case isUniqueViolation(err):
+			if err := sp.Rollback(ctx); err != nil {
+				return nil, fmt.Errorf("rollback savepoint: %w", err)
+			}
+			results = append(results, BulkInviteResult{Email: email, Status: "already_invited"})
+		default:
+			return nil, fmt.Errorf("invite %s: %w", email, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return results, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation
+}
@@ -0,0 +1,136 @@
+package pgstore
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Notification is a single queued channel delivery, drained by
+// notifier.Worker. Rows are inserted by Notifier.Send and never by the
+// delivery goroutine, so a crash mid-send just leaves the row pending for
+// the next drain instead of losing it.
+type Notification struct {
+	ID         uuid.UUID
+	TemplateID string
+	Channel    string
+	Email      string
+	Phone      string
+	PushToken  string
+	WebhookURL string
+	Payload    []byte
+	Status     string
+	Attempts   int32
+	LastError  string
+}
+
+type EnqueueNotificationParams struct {
+	TemplateID string
+	Channel    string
+	Email      string
+	Phone      string
+	PushToken  string
+	WebhookURL string
+	Payload    []byte
+}
+
+const enqueueNotification = `
+INSERT INTO notifications (template_id, channel, email, phone, push_token, webhook_url, payload, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7, 'pending')
+RETURNING id
+`
+
+func (q *Queries) EnqueueNotification(ctx context.Context, arg EnqueueNotificationParams) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := q.db.QueryRow(
+		ctx,
+		enqueueNotification,
+		arg.TemplateID,
+		arg.Channel,
+		arg.Email,
+		arg.Phone,
+		arg.PushToken,
+		arg.WebhookURL,
+		arg.Payload,
+	).Scan(&id)
+	return id, err
+}
+
+const listPendingNotifications = `
+SELECT id, template_id, channel, email, phone, push_token, webhook_url, payload, status, attempts, last_error
+FROM notifications
+WHERE status = 'pending'
+ORDER BY created_at
+LIMIT $1
+`
+
+func (q *Queries) ListPendingNotifications(ctx context.Context, limit int32) ([]Notification, error) {
+	rows, err := q.db.Query(ctx, listPendingNotifications, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []Notification
+	for rows.Next() {
+		var n Notification
+		if err := rows.Scan(
+			&n.ID, &n.TemplateID, &n.Channel, &n.Email, &n.Phone, &n.PushToken,
+			&n.WebhookURL, &n.Payload, &n.Status, &n.Attempts, &n.LastError,
+		); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+const markNotificationSent = `UPDATE notifications SET status = 'sent', sent_at = now() WHERE id = $1`
+
+func (q *Queries) MarkNotificationSent(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, markNotificationSent, id)
+	return err
+}
+
+// MaxNotificationAttempts caps how many failed delivery attempts Worker makes
+// on a notification before parking it in 'dead_letter' instead of 'pending', so
+// a permanently-failing row stops being re-drained ahead of newer ones.
+const MaxNotificationAttempts = 5
+
+type MarkNotificationFailedParams struct {
+	ID        uuid.UUID
+	LastError string
+}
+
+const markNotificationFailed = `
+UPDATE notifications
+SET status = CASE WHEN attempts + 1 >= $3 THEN 'dead_letter' ELSE 'pending' END,
+    attempts = attempts + 1,
+    last_error = $2
+WHERE id = $1
+`
+
+func (q *Queries) MarkNotificationFailed(ctx context.Context, arg MarkNotificationFailedParams) error {
+	_, err := q.db.Exec(ctx, markNotificationFailed, arg.ID, arg.LastError, MaxNotificationAttempts)
+	return err
+}
+
+const setParticipantChannelPreferences = `UPDATE participants SET channel_prefs = $2 WHERE id = $1`
+
+// SetParticipantChannelPreferences records which channels (email, sms, push,
+// webhook) a participant wants to be notified on, in priority order.
+func (q *Queries) SetParticipantChannelPreferences(ctx context.Context, participantID uuid.UUID, channels []string) error {
+	_, err := q.db.Exec(ctx, setParticipantChannelPreferences, participantID, channels)
+	return err
+}
+
+const getParticipantChannelPreferences = `SELECT channel_prefs FROM participants WHERE id = $1`
+
+// GetParticipantChannelPreferences returns the channels a participant asked
+// to be notified on, in priority order. An empty slice means no preference
+// was ever recorded, and the caller should fall back to email.
+func (q *Queries) GetParticipantChannelPreferences(ctx context.Context, participantID uuid.UUID) ([]string, error) {
+	var channels []string
+	err := q.db.QueryRow(ctx, getParticipantChannelPreferences, participantID).Scan(&channels)
+	return channels, err
+}
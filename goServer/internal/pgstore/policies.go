@@ -0,0 +1,74 @@
+package pgstore
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Policy links a subject user to an object trip with a relation (owner,
+// editor, viewer), backing the authz package's access checks.
+type Policy struct {
+	SubjectUserID uuid.UUID
+	ObjectTripID  uuid.UUID
+	Relation      string
+}
+
+type InsertPolicyParams struct {
+	SubjectUserID uuid.UUID
+	ObjectTripID  uuid.UUID
+	Relation      string
+}
+
+const insertPolicy = `
+INSERT INTO policies (subject_user_id, object_trip_id, relation)
+VALUES ($1, $2, $3)
+ON CONFLICT (subject_user_id, object_trip_id) DO UPDATE SET relation = excluded.relation
+`
+
+func (q *Queries) InsertPolicy(ctx context.Context, arg InsertPolicyParams) error {
+	_, err := q.db.Exec(ctx, insertPolicy, arg.SubjectUserID, arg.ObjectTripID, arg.Relation)
+	return err
+}
+
+type GetPolicyRelationParams struct {
+	SubjectUserID uuid.UUID
+	ObjectTripID  uuid.UUID
+}
+
+const getPolicyRelation = `
+SELECT relation FROM policies WHERE subject_user_id = $1 AND object_trip_id = $2
+`
+
+func (q *Queries) GetPolicyRelation(ctx context.Context, arg GetPolicyRelationParams) (string, error) {
+	var relation string
+	err := q.db.QueryRow(ctx, getPolicyRelation, arg.SubjectUserID, arg.ObjectTripID).Scan(&relation)
+	return relation, err
+}
+
+type ListPolicyObjectsParams struct {
+	SubjectUserID uuid.UUID
+	Relations     []string
+}
+
+const listPolicyObjects = `
+SELECT object_trip_id FROM policies WHERE subject_user_id = $1 AND relation = ANY($2)
+`
+
+func (q *Queries) ListPolicyObjects(ctx context.Context, arg ListPolicyObjectsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listPolicyObjects, arg.SubjectUserID, arg.Relations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var objects []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		objects = append(objects, id)
+	}
+	return objects, rows.Err()
+}
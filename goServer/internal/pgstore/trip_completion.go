@@ -0,0 +1,62 @@
+package pgstore
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TripCompletion holds the post-trip telemetry recorded once a trip is
+// marked complete.
+type TripCompletion struct {
+	IsComplete      bool
+	EndedAt         pgtype.Timestamp
+	Rating          pgtype.Int2
+	DistanceKm      pgtype.Numeric
+	AverageSpeedKmh pgtype.Numeric
+	Notes           string
+}
+
+type CompleteTripParams struct {
+	ID              uuid.UUID
+	EndedAt         pgtype.Timestamp
+	Rating          pgtype.Int2
+	DistanceKm      pgtype.Numeric
+	AverageSpeedKmh pgtype.Numeric
+	Notes           string
+}
+
+const completeTrip = `
+UPDATE trips
+SET is_complete = true, ended_at = $2, rating = $3, distance_km = $4, average_speed_kmh = $5, notes = $6
+WHERE id = $1
+`
+
+func (q *Queries) CompleteTrip(ctx context.Context, arg CompleteTripParams) error {
+	_, err := q.db.Exec(ctx, completeTrip,
+		arg.ID, arg.EndedAt, arg.Rating, arg.DistanceKm, arg.AverageSpeedKmh, arg.Notes,
+	)
+	return err
+}
+
+const getTripCompletion = `
+SELECT is_complete, ended_at, rating, distance_km, average_speed_kmh, notes
+FROM trips
+WHERE id = $1
+`
+
+func (q *Queries) GetTripCompletion(ctx context.Context, tripID uuid.UUID) (TripCompletion, error) {
+	var c TripCompletion
+	err := q.db.QueryRow(ctx, getTripCompletion, tripID).Scan(
+		&c.IsComplete, &c.EndedAt, &c.Rating, &c.DistanceKm, &c.AverageSpeedKmh, &c.Notes,
+	)
+	return c, err
+}
+
+const reopenTrip = `UPDATE trips SET is_complete = false WHERE id = $1`
+
+func (q *Queries) ReopenTrip(ctx context.Context, tripID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, reopenTrip, tripID)
+	return err
+}
@@ -0,0 +1,243 @@
+package pgstore
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Webhook is a trip owner's subscription to a set of domain events.
+type Webhook struct {
+	ID        uuid.UUID
+	TripID    uuid.UUID
+	URL       string
+	Secret    string
+	Events    []string
+	CreatedAt pgtype.Timestamp
+}
+
+type CreateWebhookParams struct {
+	TripID uuid.UUID
+	URL    string
+	Secret string
+	Events []string
+}
+
+const createWebhook = `
+INSERT INTO webhooks (trip_id, url, secret, events)
+VALUES ($1, $2, $3, $4)
+RETURNING id, trip_id, url, secret, events, created_at
+`
+
+func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error) {
+	var w Webhook
+	err := q.db.QueryRow(ctx, createWebhook, arg.TripID, arg.URL, arg.Secret, arg.Events).Scan(
+		&w.ID, &w.TripID, &w.URL, &w.Secret, &w.Events, &w.CreatedAt,
+	)
+	return w, err
+}
+
+const listWebhooks = `
+SELECT id, trip_id, url, secret, events, created_at FROM webhooks WHERE trip_id = $1
+`
+
+func (q *Queries) ListWebhooks(ctx context.Context, tripID uuid.UUID) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, listWebhooks, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []Webhook
+	for rows.Next() {
+		var w Webhook
+		if err := rows.Scan(&w.ID, &w.TripID, &w.URL, &w.Secret, &w.Events, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+type DeleteWebhookParams struct {
+	ID     uuid.UUID
+	TripID uuid.UUID
+}
+
+const deleteWebhook = `DELETE FROM webhooks WHERE id = $1 AND trip_id = $2`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, arg DeleteWebhookParams) error {
+	_, err := q.db.Exec(ctx, deleteWebhook, arg.ID, arg.TripID)
+	return err
+}
+
+type ListWebhooksForEventParams struct {
+	TripID uuid.UUID
+	Event  string
+}
+
+const listWebhooksForEvent = `
+SELECT id, trip_id, url, secret, events, created_at
+FROM webhooks
+WHERE trip_id = $1 AND $2 = ANY(events)
+`
+
+func (q *Queries) ListWebhooksForEvent(ctx context.Context, arg ListWebhooksForEventParams) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, listWebhooksForEvent, arg.TripID, arg.Event)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []Webhook
+	for rows.Next() {
+		var w Webhook
+		if err := rows.Scan(&w.ID, &w.TripID, &w.URL, &w.Secret, &w.Events, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+// WebhookDelivery is a single attempt (and retry history) of delivering one
+// event to one webhook. URL and Secret are joined in from the parent
+// webhook so Worker doesn't need a second round trip to send it.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	WebhookID      uuid.UUID
+	URL            string
+	Secret         string
+	EventID        uuid.UUID
+	EventType      string
+	Payload        []byte
+	Status         string
+	Attempts       int32
+	ResponseStatus int32
+	ResponseBody   string
+}
+
+type EnqueueWebhookDeliveryParams struct {
+	WebhookID uuid.UUID
+	EventID   uuid.UUID
+	EventType string
+	Payload   []byte
+}
+
+const enqueueWebhookDelivery = `
+INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload, status, next_attempt_at)
+VALUES ($1, $2, $3, $4, 'pending', now())
+RETURNING id
+`
+
+func (q *Queries) EnqueueWebhookDelivery(ctx context.Context, arg EnqueueWebhookDeliveryParams) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := q.db.QueryRow(ctx, enqueueWebhookDelivery, arg.WebhookID, arg.EventID, arg.EventType, arg.Payload).Scan(&id)
+	return id, err
+}
+
+const listDueWebhookDeliveries = `
+SELECT d.id, d.webhook_id, w.url, w.secret, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.response_status, d.response_body
+FROM webhook_deliveries d
+JOIN webhooks w ON w.id = d.webhook_id
+WHERE d.status = 'pending' AND d.next_attempt_at <= now()
+ORDER BY d.next_attempt_at
+LIMIT $1
+`
+
+func (q *Queries) ListDueWebhookDeliveries(ctx context.Context, limit int32) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listDueWebhookDeliveries, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		var d WebhookDelivery
+		if err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.URL, &d.Secret, &d.EventID, &d.EventType,
+			&d.Payload, &d.Status, &d.Attempts, &d.ResponseStatus, &d.ResponseBody,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+type MarkWebhookDeliveryDeliveredParams struct {
+	ID             uuid.UUID
+	ResponseStatus int32
+	ResponseBody   string
+}
+
+const markWebhookDeliveryDelivered = `
+UPDATE webhook_deliveries
+SET status = 'delivered', attempts = attempts + 1, response_status = $2, response_body = $3
+WHERE id = $1
+`
+
+func (q *Queries) MarkWebhookDeliveryDelivered(ctx context.Context, arg MarkWebhookDeliveryDeliveredParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliveryDelivered, arg.ID, arg.ResponseStatus, arg.ResponseBody)
+	return err
+}
+
+type MarkWebhookDeliveryFailedParams struct {
+	ID             uuid.UUID
+	ResponseStatus int32
+	ResponseBody   string
+	NextAttemptAt  pgtype.Timestamp
+	GiveUp         bool
+}
+
+const markWebhookDeliveryFailed = `
+UPDATE webhook_deliveries
+SET
+	status = CASE WHEN $5 THEN 'failed' ELSE 'pending' END,
+	attempts = attempts + 1,
+	response_status = $2,
+	response_body = $3,
+	next_attempt_at = COALESCE($4, next_attempt_at)
+WHERE id = $1
+`
+
+func (q *Queries) MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliveryFailed, arg.ID, arg.ResponseStatus, arg.ResponseBody, arg.NextAttemptAt, arg.GiveUp)
+	return err
+}
+
+type ListWebhookDeliveriesParams struct {
+	WebhookID uuid.UUID
+	TripID    uuid.UUID
+}
+
+const listWebhookDeliveries = `
+SELECT d.id, d.webhook_id, w.url, w.secret, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.response_status, d.response_body
+FROM webhook_deliveries d
+JOIN webhooks w ON w.id = d.webhook_id
+WHERE d.webhook_id = $1 AND w.trip_id = $2
+ORDER BY d.id
+`
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveries, arg.WebhookID, arg.TripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		var d WebhookDelivery
+		if err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.URL, &d.Secret, &d.EventID, &d.EventType,
+			&d.Payload, &d.Status, &d.Attempts, &d.ResponseStatus, &d.ResponseBody,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
@@ -0,0 +1,135 @@
+// Package webhook lets a trip owner subscribe an HTTPS URL to trip domain
+// events and delivers them in the background with retries, mirroring the
+// outgoing-webhook conventions used by Mattermost and Courier.
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"server/internal/pgstore"
+)
+
+// EventType is a domain event a subscription can listen for.
+type EventType string
+
+const (
+	EventTripCreated          EventType = "trip.created"
+	EventTripConfirmed        EventType = "trip.confirmed"
+	EventTripUpdated          EventType = "trip.updated"
+	EventParticipantInvited   EventType = "participant.invited"
+	EventParticipantConfirmed EventType = "participant.confirmed"
+	EventActivityCreated      EventType = "activity.created"
+	EventLinkCreated          EventType = "link.created"
+)
+
+// backoffSchedule is how long Worker waits before each retry: 1m, 5m, 30m,
+// 2h, 12h. A delivery that still fails after its sixth attempt (the fifth
+// retry, exhausting every tier including the 12h one) is given up on and
+// left in webhook_deliveries for the debug endpoint to inspect.
+var backoffSchedule = []time.Duration{
+	1 * time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	12 * time.Hour,
+}
+
+const maxAttempts = int32(len(backoffSchedule)) + 1
+
+type store interface {
+	CreateWebhook(ctx context.Context, arg pgstore.CreateWebhookParams) (pgstore.Webhook, error)
+	ListWebhooks(ctx context.Context, tripID uuid.UUID) ([]pgstore.Webhook, error)
+	DeleteWebhook(ctx context.Context, arg pgstore.DeleteWebhookParams) error
+	ListWebhooksForEvent(ctx context.Context, arg pgstore.ListWebhooksForEventParams) ([]pgstore.Webhook, error)
+	EnqueueWebhookDelivery(ctx context.Context, arg pgstore.EnqueueWebhookDeliveryParams) (uuid.UUID, error)
+	ListWebhookDeliveries(ctx context.Context, arg pgstore.ListWebhookDeliveriesParams) ([]pgstore.WebhookDelivery, error)
+}
+
+// Manager registers subscriptions and fans domain events out to the ones
+// listening for them.
+type Manager struct {
+	store  store
+	logger *zap.Logger
+}
+
+func NewManager(store store, logger *zap.Logger) *Manager {
+	return &Manager{store: store, logger: logger}
+}
+
+// Subscribe registers url to receive events for tripID, generating a fresh
+// per-webhook secret used to sign every delivery.
+func (m *Manager) Subscribe(ctx context.Context, tripID uuid.UUID, url string, events []string) (pgstore.Webhook, error) {
+	secret, err := generateSecret()
+	if err != nil {
+		return pgstore.Webhook{}, fmt.Errorf("webhook: generate secret: %w", err)
+	}
+
+	webhook, err := m.store.CreateWebhook(ctx, pgstore.CreateWebhookParams{
+		TripID: tripID,
+		URL:    url,
+		Secret: secret,
+		Events: events,
+	})
+	if err != nil {
+		return pgstore.Webhook{}, fmt.Errorf("webhook: create: %w", err)
+	}
+	return webhook, nil
+}
+
+func (m *Manager) List(ctx context.Context, tripID uuid.UUID) ([]pgstore.Webhook, error) {
+	return m.store.ListWebhooks(ctx, tripID)
+}
+
+func (m *Manager) Unsubscribe(ctx context.Context, tripID, webhookID uuid.UUID) error {
+	return m.store.DeleteWebhook(ctx, pgstore.DeleteWebhookParams{ID: webhookID, TripID: tripID})
+}
+
+func (m *Manager) Deliveries(ctx context.Context, tripID, webhookID uuid.UUID) ([]pgstore.WebhookDelivery, error) {
+	return m.store.ListWebhookDeliveries(ctx, pgstore.ListWebhookDeliveriesParams{WebhookID: webhookID, TripID: tripID})
+}
+
+// Emit persists one delivery per subscription on tripID listening for
+// event, so a slow or down endpoint never blocks the request that
+// triggered the event; Worker.Run does the actual sending.
+func (m *Manager) Emit(ctx context.Context, tripID uuid.UUID, event EventType, payload any) error {
+	subscriptions, err := m.store.ListWebhooksForEvent(ctx, pgstore.ListWebhooksForEventParams{
+		TripID: tripID,
+		Event:  string(event),
+	})
+	if err != nil {
+		return fmt.Errorf("webhook: list subscriptions: %w", err)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("webhook: marshal payload: %w", err)
+	}
+
+	for _, sub := range subscriptions {
+		_, err := m.store.EnqueueWebhookDelivery(ctx, pgstore.EnqueueWebhookDeliveryParams{
+			WebhookID: sub.ID,
+			EventID:   uuid.New(),
+			EventType: string(event),
+			Payload:   body,
+		})
+		if err != nil {
+			m.logger.Error("failed to enqueue webhook delivery", zap.Error(err), zap.String("webhook_id", sub.ID.String()))
+		}
+	}
+	return nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
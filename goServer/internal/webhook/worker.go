@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+	"server/internal/pgstore"
+)
+
+const drainInterval = 30 * time.Second
+
+// deliveryStore is the subset of pgstore Worker needs to drain and retry
+// deliveries independently of Manager's registration-side methods.
+type deliveryStore interface {
+	ListDueWebhookDeliveries(ctx context.Context, limit int32) ([]pgstore.WebhookDelivery, error)
+	MarkWebhookDeliveryDelivered(ctx context.Context, arg pgstore.MarkWebhookDeliveryDeliveredParams) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg pgstore.MarkWebhookDeliveryFailedParams) error
+}
+
+// Worker drains due webhook_deliveries rows on a fixed interval, signs and
+// POSTs each one, and reschedules failures per backoffSchedule.
+type Worker struct {
+	store  deliveryStore
+	client *http.Client
+	logger *zap.Logger
+}
+
+func NewWorker(store deliveryStore, logger *zap.Logger) *Worker {
+	return &Worker{store: store, client: http.DefaultClient, logger: logger}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(drainInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.drain(ctx)
+		}
+	}
+}
+
+func (w *Worker) drain(ctx context.Context) {
+	due, err := w.store.ListDueWebhookDeliveries(ctx, 50)
+	if err != nil {
+		w.logger.Error("failed to list due webhook deliveries", zap.Error(err))
+		return
+	}
+	for _, delivery := range due {
+		w.attempt(ctx, delivery)
+	}
+}
+
+func (w *Worker) attempt(ctx context.Context, delivery pgstore.WebhookDelivery) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		w.logger.Error("failed to build webhook request", zap.Error(err), zap.String("delivery_id", delivery.ID.String()))
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature", sign(delivery.Secret, delivery.Payload))
+	req.Header.Set("X-Event-Id", delivery.EventID.String())
+	req.Header.Set("X-Event-Type", delivery.EventType)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		w.fail(ctx, delivery, 0, err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 300 {
+		w.fail(ctx, delivery, resp.StatusCode, string(body))
+		return
+	}
+
+	if err := w.store.MarkWebhookDeliveryDelivered(ctx, pgstore.MarkWebhookDeliveryDeliveredParams{
+		ID:             delivery.ID,
+		ResponseStatus: int32(resp.StatusCode),
+		ResponseBody:   string(body),
+	}); err != nil {
+		w.logger.Error("failed to mark webhook delivery delivered", zap.Error(err), zap.String("delivery_id", delivery.ID.String()))
+	}
+}
+
+func (w *Worker) fail(ctx context.Context, delivery pgstore.WebhookDelivery, status int, body string) {
+	attempt := delivery.Attempts
+	giveUp := attempt+1 >= maxAttempts
+
+	var nextAttemptAt pgtype.Timestamp
+	if !giveUp {
+		nextAttemptAt = pgtype.Timestamp{Valid: true, Time: time.Now().Add(backoffSchedule[attempt])}
+	}
+
+	err := w.store.MarkWebhookDeliveryFailed(ctx, pgstore.MarkWebhookDeliveryFailedParams{
+		ID:             delivery.ID,
+		ResponseStatus: int32(status),
+		ResponseBody:   body,
+		NextAttemptAt:  nextAttemptAt,
+		GiveUp:         giveUp,
+	})
+	if err != nil {
+		w.logger.Error("failed to mark webhook delivery failed", zap.Error(err), zap.String("delivery_id", delivery.ID.String()))
+	}
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}